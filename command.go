@@ -0,0 +1,143 @@
+// Copyright 2014 Tom Grennan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sos
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Handler processes the SoS remaining after a Command's path has been
+// consumed (any flags and positionals that followed it on the line).
+type Handler func(SoS) error
+
+// Command is one node of the trie registered with a Dispatcher. Each
+// Command may have its own Handler (git-style groups like `remote` need
+// none, since every use ends in a leaf like `remote add`) and its own
+// nested subcommands.
+type Command struct {
+	Name    string
+	Usage   string
+	Handler Handler
+	aliases []string
+	subs    map[string]*Command
+}
+
+// Create a Command, ready to accept nested subcommands via Sub.
+func NewCommand(name, usage string, handler Handler) *Command {
+	return &Command{
+		Name:    name,
+		Usage:   usage,
+		Handler: handler,
+		subs:    make(map[string]*Command),
+	}
+}
+
+// Register a nested subcommand and return it, so callers can chain
+// further Sub calls to build deeper paths.
+func (c *Command) Sub(sub *Command) *Command {
+	c.subs[sub.Name] = sub
+	for _, alias := range sub.aliases {
+		c.subs[alias] = sub
+	}
+	return sub
+}
+
+// Register additional names that also resolve to c when looked up by
+// its parent. The parent records c's aliases when c is registered with
+// Sub or Add; names added after that are not seen by the parent.
+func (c *Command) Alias(names ...string) *Command {
+	c.aliases = append(c.aliases, names...)
+	return c
+}
+
+// Dispatcher roots a trie of Commands and routes a program's arguments
+// to the deepest matching one.
+type Dispatcher struct {
+	root    *Command
+	Default Handler
+}
+
+// Create a Dispatcher with an anonymous root; register top-level
+// commands with Add.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{root: NewCommand("", "", nil)}
+}
+
+// Register a top-level Command.
+func (d *Dispatcher) Add(c *Command) *Command {
+	return d.root.Sub(c)
+}
+
+// Pop the program name, then walk the trie consuming positional tokens
+// until the deepest matching Command is found, and invoke its Handler
+// with whatever SoS remains. If no path matches and a Default handler
+// is set, it runs with the original sos (program name already popped).
+// If neither a match nor a Default exists, Run returns an error: naming
+// the unrecognized token if one was given, or reporting that none was
+// given at all.
+func (d *Dispatcher) Run(sos SoS) error {
+	sos, _ = sos.Pop()
+	node := d.root
+	var path []string
+	for {
+		next, name := sos.Pop()
+		sub, ok := node.subs[name]
+		if !ok {
+			break
+		}
+		sos = next
+		node = sub
+		path = append(path, node.Name)
+	}
+	if node.Handler != nil {
+		return node.Handler(sos)
+	}
+	if d.Default != nil {
+		return d.Default(sos)
+	}
+	if len(path) == 0 {
+		if sos.Len() == 0 {
+			return fmt.Errorf("sos: no command given")
+		}
+		return fmt.Errorf("sos: %s: no such command", sos.String(0))
+	}
+	return fmt.Errorf("sos: %s: incomplete command", strings.Join(path, " "))
+}
+
+// Render the registered command tree rooted at path (the full tree if
+// no path is given) as indented, one-name-per-line text suitable for a
+// program's `help` output.
+func (d *Dispatcher) HelpFor(path ...string) string {
+	node := d.root
+	for _, name := range path {
+		sub, ok := node.subs[name]
+		if !ok {
+			return fmt.Sprintf("sos: %s: no such command", strings.Join(path, " "))
+		}
+		node = sub
+	}
+	var b strings.Builder
+	renderHelp(&b, node, 0)
+	return b.String()
+}
+
+func renderHelp(b *strings.Builder, node *Command, depth int) {
+	subs := make([]*Command, 0, len(node.subs))
+	seen := make(map[*Command]bool)
+	for _, sub := range node.subs {
+		if seen[sub] {
+			continue
+		}
+		seen[sub] = true
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Name < subs[j].Name })
+	for _, sub := range subs {
+		fmt.Fprintf(b, "%s%s\t%s\n", strings.Repeat("  ", depth), sub.Name, sub.Usage)
+		renderHelp(b, sub, depth+1)
+	}
+}
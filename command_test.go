@@ -0,0 +1,113 @@
+// Copyright 2014 Tom Grennan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sos_test
+
+import (
+	"github.com/tgrennan/sos"
+	"strings"
+	"testing"
+)
+
+func TestDispatcher(t *testing.T) {
+	var gotName, gotURL string
+	d := sos.NewDispatcher()
+	remote := d.Add(sos.NewCommand("remote", "manage remotes", nil))
+	remote.Sub(sos.NewCommand("add", "add a remote", func(args sos.SoS) error {
+		gotName = args.String(0)
+		gotURL = args.String(1)
+		return nil
+	}))
+	var ranDefault bool
+	d.Default = func(sos.SoS) error {
+		ranDefault = true
+		return nil
+	}
+
+	if err := d.Run(sos.New("prog", "remote", "add", "origin", "url")); err != nil {
+		t.Fatal(err)
+	}
+	if gotName != "origin" || gotURL != "url" {
+		t.Fatal("got:", gotName, gotURL)
+	}
+
+	if err := d.Run(sos.New("prog", "unknown")); err != nil {
+		t.Fatal(err)
+	}
+	if !ranDefault {
+		t.Fatal("default handler did not run")
+	}
+}
+
+func TestDispatcherAlias(t *testing.T) {
+	var ran bool
+	d := sos.NewDispatcher()
+	d.Add(sos.NewCommand("status", "show status", func(sos.SoS) error {
+		ran = true
+		return nil
+	}).Alias("st"))
+
+	if err := d.Run(sos.New("prog", "st")); err != nil {
+		t.Fatal(err)
+	}
+	if !ran {
+		t.Fatal("alias did not dispatch")
+	}
+}
+
+func TestDispatcherIncomplete(t *testing.T) {
+	d := sos.NewDispatcher()
+	d.Add(sos.NewCommand("remote", "manage remotes", nil))
+
+	if err := d.Run(sos.New("prog", "remote")); err == nil {
+		t.Fatal("expected error for incomplete command")
+	}
+}
+
+func TestDispatcherUnrecognized(t *testing.T) {
+	d := sos.NewDispatcher()
+	d.Add(sos.NewCommand("remote", "manage remotes", nil))
+
+	err := d.Run(sos.New("prog", "frobnicate"))
+	if err == nil {
+		t.Fatal("expected error for unrecognized command")
+	}
+	if !strings.Contains(err.Error(), "frobnicate") {
+		t.Fatalf("expected error to name the unrecognized token, got: %v", err)
+	}
+
+	err = d.Run(sos.New("prog"))
+	if err == nil {
+		t.Fatal("expected error for no command given")
+	}
+	if strings.Contains(err.Error(), "frobnicate") {
+		t.Fatalf("unexpected token in no-command error: %v", err)
+	}
+}
+
+func TestHelpFor(t *testing.T) {
+	d := sos.NewDispatcher()
+	remote := d.Add(sos.NewCommand("remote", "manage remotes", nil))
+	remote.Sub(sos.NewCommand("add", "add a remote", func(sos.SoS) error { return nil }))
+
+	help := d.HelpFor()
+	if help == "" {
+		t.Fatal("expected non-empty help")
+	}
+}
+
+func TestHelpForAliasCanonicalName(t *testing.T) {
+	d := sos.NewDispatcher()
+	d.Add(sos.NewCommand("status", "show status", func(sos.SoS) error { return nil }).Alias("st"))
+
+	for i := 0; i < 50; i++ {
+		help := d.HelpFor()
+		if !strings.Contains(help, "status\tshow status") {
+			t.Fatalf("run %d: expected canonical name in help, got:\n%s", i, help)
+		}
+		if strings.Contains(help, "st\tshow status") {
+			t.Fatalf("run %d: alias rendered instead of canonical name:\n%s", i, help)
+		}
+	}
+}
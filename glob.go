@@ -0,0 +1,173 @@
+// Copyright 2014 Tom Grennan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sos
+
+import "strings"
+
+// globState is one node of the Thompson-style NFA compiled from a glob
+// pattern, consulted in sequence by matchGlob.
+type globState struct {
+	kind globKind
+	r    rune   // for globChar, the literal rune to match
+	set  string // for globClass, the characters inside [...]
+	neg  bool   // for globClass, whether the class is negated [^...]
+}
+
+type globKind int
+
+const (
+	globChar  globKind = iota // a literal rune
+	globAny                   // '?'
+	globStar                  // '*', matches within one path segment
+	globSuper                 // '**', matches across segments
+	globClass                 // '[...]'
+	globEnd                   // accepting state
+)
+
+// Compile a glob pattern into its NFA. '*' matches any run of runes
+// except '/'; '**' matches any run of runes including '/'; '?' matches
+// exactly one rune; '[...]' and '[^...]' match a class of runes.
+func compileGlob(pattern string) []globState {
+	var states []globState
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); {
+		switch runes[i] {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				states = append(states, globState{kind: globSuper})
+				i += 2
+			} else {
+				states = append(states, globState{kind: globStar})
+				i++
+			}
+		case '?':
+			states = append(states, globState{kind: globAny})
+			i++
+		case '[':
+			j := i + 1
+			neg := false
+			if j < len(runes) && (runes[j] == '^' || runes[j] == '!') {
+				neg = true
+				j++
+			}
+			start := j
+			for j < len(runes) && runes[j] != ']' {
+				j++
+			}
+			states = append(states, globState{
+				kind: globClass,
+				set:  string(runes[start:j]),
+				neg:  neg,
+			})
+			i = j + 1
+		default:
+			states = append(states, globState{kind: globChar, r: runes[i]})
+			i++
+		}
+	}
+	states = append(states, globState{kind: globEnd})
+	return states
+}
+
+// Report whether s fully matches the compiled glob pattern, trying
+// every way a '*' or '**' state can extend its match (backtracking
+// NFA simulation, anchored at both ends of s).
+func matchGlob(states []globState, si int, s []rune) bool {
+	if si >= len(states) {
+		return len(s) == 0
+	}
+	st := states[si]
+	switch st.kind {
+	case globEnd:
+		return len(s) == 0
+	case globChar:
+		return len(s) > 0 && s[0] == st.r && matchGlob(states, si+1, s[1:])
+	case globAny:
+		return len(s) > 0 && matchGlob(states, si+1, s[1:])
+	case globClass:
+		if len(s) == 0 {
+			return false
+		}
+		in := strings.ContainsRune(st.set, s[0])
+		if in == st.neg {
+			return false
+		}
+		return matchGlob(states, si+1, s[1:])
+	case globStar:
+		for n := 0; n <= len(s); n++ {
+			if n > 0 && s[n-1] == '/' {
+				break
+			}
+			if matchGlob(states, si+1, s[n:]) {
+				return true
+			}
+		}
+		return false
+	case globSuper:
+		for n := 0; n <= len(s); n++ {
+			if matchGlob(states, si+1, s[n:]) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// Report whether flag fully matches pattern, per the rules of
+// compileGlob: '*' and '?' as usual, '**' additionally crossing '/'
+// segment boundaries, and '[...]'/'[^...]' character classes.
+func Glob(pattern, flag string) bool {
+	return matchGlob(compileGlob(pattern), 0, []rune(flag))
+}
+
+// Returns and strips every flag whose name matches pattern, collecting
+// each as "name=value". A flag given without '=' takes the following
+// token as its value (empty if the flag is the last token).
+func (sos SoS) ArgGlob(pattern string) (SoS, []string) {
+	var matches []string
+	states := compileGlob(pattern)
+	for i := 0; i < len(sos); {
+		s := sos[i]
+		if !strings.HasPrefix(s, "-") {
+			i++
+			continue
+		}
+		t := strings.TrimLeft(s, "-")
+		if iequal := strings.Index(t, "="); iequal > 0 {
+			name, value := t[:iequal], t[iequal+1:]
+			if matchGlob(states, 0, []rune(name)) {
+				matches = append(matches, name+"="+value)
+				sos = sos.Remove(i, 1)
+				continue
+			}
+		} else if matchGlob(states, 0, []rune(t)) {
+			value := sos.String(i + 1)
+			matches = append(matches, t+"="+value)
+			sos = sos.Remove(i, 2)
+			continue
+		}
+		i++
+	}
+	return sos, matches
+}
+
+// Returns and strips every boolean flag whose name matches pattern.
+func (sos SoS) FlagGlob(pattern string) (SoS, []string) {
+	var matches []string
+	states := compileGlob(pattern)
+	for i := 0; i < len(sos); {
+		s := sos[i]
+		if strings.HasPrefix(s, "-") {
+			if t := strings.TrimLeft(s, "-"); matchGlob(states, 0, []rune(t)) {
+				matches = append(matches, t)
+				sos = sos.Remove(i, 1)
+				continue
+			}
+		}
+		i++
+	}
+	return sos, matches
+}
@@ -0,0 +1,60 @@
+// Copyright 2014 Tom Grennan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sos_test
+
+import (
+	"github.com/tgrennan/sos"
+	"testing"
+)
+
+func TestGlob(t *testing.T) {
+	cases := []struct {
+		pattern, flag string
+		want          bool
+	}{
+		{"v*", "verbose", true},
+		{"v*", "v", true},
+		{"v*", "x", false},
+		{"v?", "vv", true},
+		{"v?", "v", false},
+		{"log-*", "log-level", true},
+		{"[abc]", "b", true},
+		{"[^abc]", "b", false},
+		{"[^abc]", "d", true},
+	}
+	for _, c := range cases {
+		if got := sos.Glob(c.pattern, c.flag); got != c.want {
+			t.Errorf("Glob(%q, %q) = %v, want %v", c.pattern, c.flag, got, c.want)
+		}
+	}
+}
+
+func TestFlagGlob(t *testing.T) {
+	s := sos.New("-v", "--verbose", "--vv", "-x")
+	s, matches := s.FlagGlob("v*")
+	if i := s.Mismatch("-x"); i >= 0 {
+		t.Fatal("mismatch at", i, "of:", s)
+	}
+	if len(matches) != 3 {
+		t.Fatal("matches:", matches)
+	}
+}
+
+func TestArgGlob(t *testing.T) {
+	s := sos.New("--log-level=debug", "--log-file", "out.log", "-x")
+	s, matches := s.ArgGlob("log-*")
+	if i := s.Mismatch("-x"); i >= 0 {
+		t.Fatal("mismatch at", i, "of:", s)
+	}
+	if len(matches) != 2 {
+		t.Fatal("matches:", matches)
+	}
+	if matches[0] != "log-level=debug" {
+		t.Fatal("matches[0]:", matches[0])
+	}
+	if matches[1] != "log-file=out.log" {
+		t.Fatal("matches[1]:", matches[1])
+	}
+}
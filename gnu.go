@@ -0,0 +1,123 @@
+// Copyright 2014 Tom Grennan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sos
+
+import "strings"
+
+// Index of the first bare "--" end-of-flags terminator, or -1 if sos
+// has none. The GNU-style methods below never match a flag at or past
+// this index; everything from there on is positional.
+func (sos SoS) EndOfFlags() int {
+	return sos.Index("--")
+}
+
+// Returns and strips the boolean flag of the matching string, GNU-style:
+// scanning stops at a bare "--" terminator, and a combined short option
+// group like "-abc" matches flag "a", "b", or "c" without disturbing
+// the other letters of the group.
+func (sos SoS) GNUFlag(flag string) (SoS, bool) {
+	end := sos.EndOfFlags()
+	for i, s := range sos {
+		if end >= 0 && i >= end {
+			break
+		}
+		if !strings.HasPrefix(s, "-") || s == "-" {
+			continue
+		}
+		if strings.HasPrefix(s, "--") {
+			if s[2:] == flag {
+				return sos.Remove(i, 1), true
+			}
+			continue
+		}
+		group := s[1:]
+		if len(flag) == 1 && strings.Contains(group, flag) {
+			if len(group) == 1 {
+				return sos.Remove(i, 1), true
+			}
+			rest := strings.Replace(group, flag, "", 1)
+			return sos.Remove(i, 1).Insert(i, "-"+rest), true
+		}
+	}
+	return sos, false
+}
+
+// Returns and strips the argument of the matching string, GNU-style:
+// scanning stops at a bare "--" terminator.
+func (sos SoS) GNUArg(flag string) (SoS, string) {
+	end := sos.EndOfFlags()
+	for i, s := range sos {
+		if end >= 0 && i >= end {
+			break
+		}
+		if !strings.HasPrefix(s, "-") {
+			continue
+		}
+		if t := strings.TrimLeft(s, "-"); t == flag {
+			arg := sos.String(i + 1)
+			return sos.Remove(i, 2), arg
+		} else if iequal := strings.Index(t, "="); iequal > 0 && t[:iequal] == flag {
+			return sos.Remove(i, 1), t[iequal+1:]
+		}
+	}
+	return sos, ""
+}
+
+// Returns and strips every occurrence of flag, whether given as
+// "--flag=value" or "--flag value", collecting each value in order and
+// stopping at a bare "--" terminator. Unlike Arg, which stops at the
+// first match, ArgAll drains them all.
+func (sos SoS) ArgAll(flag string) (SoS, []string) {
+	var values []string
+	for {
+		end := sos.EndOfFlags()
+		matched := false
+		for i, s := range sos {
+			if end >= 0 && i >= end {
+				break
+			}
+			if !strings.HasPrefix(s, "-") {
+				continue
+			}
+			t := strings.TrimLeft(s, "-")
+			if t == flag {
+				values = append(values, sos.String(i+1))
+				sos = sos.Remove(i, 2)
+				matched = true
+				break
+			} else if iequal := strings.Index(t, "="); iequal > 0 && t[:iequal] == flag {
+				values = append(values, t[iequal+1:])
+				sos = sos.Remove(i, 1)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			break
+		}
+	}
+	return sos, values
+}
+
+// Returns and strips the first "--flag=value" occurrence, stopping at a
+// bare "--" terminator, and distinguishing it from "--flag value": the
+// bool is true only when the value was attached with '=' rather than
+// given as a separate token.
+func (sos SoS) Attached(flag string) (SoS, string, bool) {
+	end := sos.EndOfFlags()
+	for i, s := range sos {
+		if end >= 0 && i >= end {
+			break
+		}
+		if !strings.HasPrefix(s, "-") {
+			continue
+		}
+		t := strings.TrimLeft(s, "-")
+		if iequal := strings.Index(t, "="); iequal > 0 && t[:iequal] == flag {
+			return sos.Remove(i, 1), t[iequal+1:], true
+		}
+	}
+	return sos, "", false
+}
@@ -0,0 +1,60 @@
+// Copyright 2014 Tom Grennan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sos_test
+
+import (
+	"github.com/tgrennan/sos"
+	"testing"
+)
+
+func TestEndOfFlags(t *testing.T) {
+	s := sos.New("-a", "--", "-b")
+	s, a := s.GNUFlag("a")
+	if !a {
+		t.Fatal("a not found")
+	}
+	if _, b := s.GNUFlag("b"); b {
+		t.Fatal("b matched past --")
+	}
+	if i := s.Mismatch("--", "-b"); i >= 0 {
+		t.Fatal("mismatch at", i, "of:", s)
+	}
+}
+
+func TestGNUFlagCombined(t *testing.T) {
+	s := sos.New("-abc", "x")
+	var a, b, c bool
+	s, a = s.GNUFlag("a")
+	s, b = s.GNUFlag("b")
+	s, c = s.GNUFlag("c")
+	if !a || !b || !c {
+		t.Fatal("a,b,c:", a, b, c)
+	}
+	if i := s.Mismatch("x"); i >= 0 {
+		t.Fatal("mismatch at", i, "of:", s)
+	}
+}
+
+func TestArgAll(t *testing.T) {
+	s := sos.New("--log-level=debug", "-x", "--log-level=info", "y")
+	s, values := s.ArgAll("log-level")
+	if i := s.Mismatch("-x", "y"); i >= 0 {
+		t.Fatal("mismatch at", i, "of:", s)
+	}
+	if len(values) != 2 || values[0] != "debug" || values[1] != "info" {
+		t.Fatal("values:", values)
+	}
+}
+
+func TestAttached(t *testing.T) {
+	s := sos.New("--level=debug", "--other", "value")
+	s, v, ok := s.Attached("level")
+	if !ok || v != "debug" {
+		t.Fatal("attached:", v, ok)
+	}
+	if _, _, ok := s.Attached("other"); ok {
+		t.Fatal("expected space-separated form to not be Attached")
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2014 Tom Grennan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed semantic version, MAJOR.MINOR.PATCH[-PRE][+BUILD]
+// per semver 2.0.0.
+type Version struct {
+	Major, Minor, Patch int
+	Pre, Build          string
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// ParseVersion parses a MAJOR.MINOR.PATCH[-PRE][+BUILD] string.
+func ParseVersion(s string) (Version, error) {
+	var v Version
+	if i := strings.Index(s, "+"); i >= 0 {
+		v.Build = s[i+1:]
+		s = s[:i]
+	}
+	if i := strings.Index(s, "-"); i >= 0 {
+		v.Pre = s[i+1:]
+		s = s[:i]
+	}
+	fields := strings.SplitN(s, ".", 3)
+	if len(fields) != 3 {
+		return v, fmt.Errorf("sos: %q is not MAJOR.MINOR.PATCH", s)
+	}
+	nums := [3]*int{&v.Major, &v.Minor, &v.Patch}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("sos: %q is not a valid version number", f)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+// Compare returns -1, 0, or 1 as v is less than, equal to, or greater
+// than o, per semver 2.0.0 precedence: MAJOR.MINOR.PATCH compare
+// numerically; a pre-release version has lower precedence than the
+// associated normal version; build metadata is ignored.
+func (v Version) Compare(o Version) int {
+	if c := compareInt(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	if v.Pre == o.Pre {
+		return 0
+	}
+	if v.Pre == "" {
+		return 1
+	}
+	if o.Pre == "" {
+		return -1
+	}
+	return comparePre(v.Pre, o.Pre)
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePre compares dot-separated pre-release identifiers per semver
+// 2.0.0: numeric identifiers compare numerically and are always lower
+// precedence than alphanumeric identifiers, which compare lexically.
+func comparePre(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aerr := strconv.Atoi(as[i])
+		bn, berr := strconv.Atoi(bs[i])
+		switch {
+		case aerr == nil && berr == nil:
+			if c := compareInt(an, bn); c != 0 {
+				return c
+			}
+		case aerr == nil:
+			return -1
+		case berr == nil:
+			return 1
+		default:
+			if as[i] != bs[i] {
+				if as[i] < bs[i] {
+					return -1
+				}
+				return 1
+			}
+		}
+	}
+	return compareInt(len(as), len(bs))
+}
+
+// Returns and strips the flag's argument parsed as a Version.
+func (sos SoS) SemverArg(flag string) (SoS, Version, error) {
+	next, s := sos.Arg(flag)
+	v, err := ParseVersion(s)
+	if err != nil {
+		return next, v, fmt.Errorf("sos: -%s: %v", flag, err)
+	}
+	return next, v, nil
+}
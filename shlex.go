@@ -0,0 +1,100 @@
+// Copyright 2014 Tom Grennan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sos
+
+import "fmt"
+
+// Create a Slice of Strings by tokenizing a single command-line string
+// with POSIX shell quoting rules: single and double quotes group
+// whitespace, a backslash escapes the following rune, and a '#' outside
+// of quotes begins a comment that runs to the end of the string.
+//
+//	sos, err := sos.NewFromString(`-a A --b="B B" -c # trailing comment`)
+//
+// Returns a descriptive error if the string has an unterminated quote
+// or a trailing backslash.
+func NewFromString(s string) (SoS, error) {
+	const (
+		stateDefault = iota
+		stateSingleQuote
+		stateDoubleQuote
+		stateEscape
+		stateComment
+	)
+	var (
+		tokens  []string
+		token   []rune
+		haveTok bool
+		state   = stateDefault
+		prev    = stateDefault
+	)
+	flush := func() {
+		if haveTok {
+			tokens = append(tokens, string(token))
+			token = token[:0]
+			haveTok = false
+		}
+	}
+	for _, r := range s {
+		switch state {
+		case stateComment:
+			continue
+		case stateEscape:
+			token = append(token, r)
+			haveTok = true
+			state = prev
+			continue
+		}
+		switch state {
+		case stateSingleQuote:
+			if r == '\'' {
+				state = stateDefault
+			} else {
+				token = append(token, r)
+				haveTok = true
+			}
+		case stateDoubleQuote:
+			switch r {
+			case '"':
+				state = stateDefault
+			case '\\':
+				prev = state
+				state = stateEscape
+			default:
+				token = append(token, r)
+				haveTok = true
+			}
+		default: // stateDefault
+			switch r {
+			case '\'':
+				state = stateSingleQuote
+				haveTok = true
+			case '"':
+				state = stateDoubleQuote
+				haveTok = true
+			case '\\':
+				prev = state
+				state = stateEscape
+			case '#':
+				state = stateComment
+			case ' ', '\t', '\n', '\r':
+				flush()
+			default:
+				token = append(token, r)
+				haveTok = true
+			}
+		}
+	}
+	switch state {
+	case stateSingleQuote:
+		return nil, fmt.Errorf("sos: unterminated single quote in %q", s)
+	case stateDoubleQuote:
+		return nil, fmt.Errorf("sos: unterminated double quote in %q", s)
+	case stateEscape:
+		return nil, fmt.Errorf("sos: trailing backslash in %q", s)
+	}
+	flush()
+	return New(tokens...), nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2014 Tom Grennan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sos_test
+
+import (
+	"github.com/tgrennan/sos"
+	"testing"
+)
+
+func TestNewFromString(t *testing.T) {
+	s, err := sos.NewFromString(`-a A --b="B B" -c 'single quoted' \# not-a-comment # trailing comment`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i := s.Mismatch("-a", "A", "--b=B B", "-c", "single quoted", "#", "not-a-comment"); i >= 0 {
+		t.Fatal("mismatch at", i, "of:", s)
+	}
+	if n := s.Len(); n != 7 {
+		t.Fatal("len:", n)
+	}
+}
+
+func TestNewFromStringErrors(t *testing.T) {
+	for _, s := range []string{
+		`-a 'unterminated`,
+		`-a "unterminated`,
+		`-a trailing\`,
+	} {
+		if _, err := sos.NewFromString(s); err == nil {
+			t.Fatal("expected error for:", s)
+		}
+	}
+}
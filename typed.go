@@ -0,0 +1,84 @@
+// Copyright 2014 Tom Grennan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Returns and strips the flag's argument parsed as a time.Duration,
+// per time.ParseDuration (e.g. "300ms", "1h30m").
+func (sos SoS) DurationArg(flag string) (SoS, time.Duration, error) {
+	next, s := sos.Arg(flag)
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return next, 0, fmt.Errorf("sos: -%s: %v", flag, err)
+	}
+	return next, d, nil
+}
+
+// byteUnits maps both SI (KB, MB, ...) and IEC (KiB, MiB, ...) suffixes
+// to their multiplier, largest first so the longest suffix wins.
+var byteUnits = []struct {
+	suffix string
+	scale  uint64
+}{
+	{"TiB", 1 << 40}, {"TB", 1e12}, {"T", 1e12},
+	{"GiB", 1 << 30}, {"GB", 1e9}, {"G", 1e9},
+	{"MiB", 1 << 20}, {"MB", 1e6}, {"M", 1e6},
+	{"KiB", 1 << 10}, {"KB", 1e3}, {"K", 1e3},
+	{"B", 1},
+}
+
+// ParseBytes parses a byte size string like "512B", "256KB", "4MiB", or
+// a bare number of bytes, supporting both SI (1000-based) and IEC
+// (1024-based) suffixes.
+func ParseBytes(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	for _, u := range byteUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			numPart := strings.TrimSuffix(s, u.suffix)
+			n, err := strconv.ParseFloat(numPart, 64)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("sos: %q is not a valid byte size", s)
+			}
+			return uint64(n * float64(u.scale)), nil
+		}
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("sos: %q is not a valid byte size", s)
+	}
+	return n, nil
+}
+
+// Returns and strips the flag's argument parsed as a byte count, per
+// ParseBytes.
+func (sos SoS) BytesArg(flag string) (SoS, uint64, error) {
+	next, s := sos.Arg(flag)
+	n, err := ParseBytes(s)
+	if err != nil {
+		return next, 0, fmt.Errorf("sos: -%s: %v", flag, err)
+	}
+	return next, n, nil
+}
+
+// Returns and strips every "-flag key=value" occurrence, collecting
+// them into a map.
+func (sos SoS) MapArg(flag string) (SoS, map[string]string, error) {
+	next, values := sos.ArgAll(flag)
+	m := make(map[string]string, len(values))
+	for _, kv := range values {
+		i := strings.Index(kv, "=")
+		if i < 0 {
+			return next, nil, fmt.Errorf("sos: -%s: %q is not key=value", flag, kv)
+		}
+		m[kv[:i]] = kv[i+1:]
+	}
+	return next, m, nil
+}
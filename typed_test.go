@@ -0,0 +1,151 @@
+// Copyright 2014 Tom Grennan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sos_test
+
+import (
+	"github.com/tgrennan/sos"
+	"testing"
+	"time"
+)
+
+func TestDurationArg(t *testing.T) {
+	s := sos.New("-t", "1h30m", "x")
+	s, d, err := s.DurationArg("t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != 90*time.Minute {
+		t.Fatal("d:", d)
+	}
+	if i := s.Mismatch("x"); i >= 0 {
+		t.Fatal("mismatch at", i, "of:", s)
+	}
+}
+
+func TestBytesArg(t *testing.T) {
+	cases := []struct {
+		s    string
+		want uint64
+	}{
+		{"512", 512},
+		{"1KB", 1000},
+		{"1KiB", 1024},
+		{"4MiB", 4 * 1024 * 1024},
+		{"1GB", 1e9},
+	}
+	for _, c := range cases {
+		s := sos.New("-b", c.s)
+		_, n, err := s.BytesArg("b")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != c.want {
+			t.Errorf("ParseBytes(%q) = %d, want %d", c.s, n, c.want)
+		}
+	}
+}
+
+func TestBytesArgNegative(t *testing.T) {
+	for _, s := range []string{"-5KB", "-1"} {
+		if n, err := sos.ParseBytes(s); err == nil {
+			t.Errorf("ParseBytes(%q) = %d, nil, want error", s, n)
+		}
+	}
+}
+
+func TestBytesArgErrorStripsCleanly(t *testing.T) {
+	s := sos.New("-b", "bogus", "keep1", "keep2")
+	s, _, err := s.BytesArg("b")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if i := s.Mismatch("keep1", "keep2"); i >= 0 {
+		t.Fatal("mismatch at", i, "of:", s)
+	}
+}
+
+func TestDurationArgErrorStripsCleanly(t *testing.T) {
+	s := sos.New("-t", "bogus", "keep1", "keep2")
+	s, _, err := s.DurationArg("t")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if i := s.Mismatch("keep1", "keep2"); i >= 0 {
+		t.Fatal("mismatch at", i, "of:", s)
+	}
+}
+
+func TestSemverArg(t *testing.T) {
+	s := sos.New("-v", "1.2.3-rc.1+build.5")
+	_, v, err := s.SemverArg("v")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Major != 1 || v.Minor != 2 || v.Patch != 3 || v.Pre != "rc.1" || v.Build != "build.5" {
+		t.Fatal("v:", v)
+	}
+}
+
+func TestSemverArgErrorStripsCleanly(t *testing.T) {
+	s := sos.New("-v", "bogus", "keep1", "keep2")
+	s, _, err := s.SemverArg("v")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if i := s.Mismatch("keep1", "keep2"); i >= 0 {
+		t.Fatal("mismatch at", i, "of:", s)
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "2.0.0", -1},
+		{"1.2.3", "1.2.3", 0},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+	}
+	for _, c := range cases {
+		av, err := sos.ParseVersion(c.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bv, err := sos.ParseVersion(c.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := av.Compare(bv); got != c.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestMapArg(t *testing.T) {
+	s := sos.New("-D", "a=1", "-D", "b=2", "x")
+	s, m, err := s.MapArg("D")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m["a"] != "1" || m["b"] != "2" || len(m) != 2 {
+		t.Fatal("m:", m)
+	}
+	if i := s.Mismatch("x"); i >= 0 {
+		t.Fatal("mismatch at", i, "of:", s)
+	}
+}
+
+func TestMapArgErrorStripsCleanly(t *testing.T) {
+	s := sos.New("-D", "noequals", "keep1", "keep2")
+	s, _, err := s.MapArg("D")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if i := s.Mismatch("keep1", "keep2"); i >= 0 {
+		t.Fatal("mismatch at", i, "of:", s)
+	}
+}